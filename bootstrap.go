@@ -3,7 +3,9 @@ package bootstrap
 import (
 	"math"
 	"math/rand"
+	"runtime"
 	"sort"
+	"sync"
 )
 
 // Aggregator aggregates a slice of floats.
@@ -79,7 +81,10 @@ type BasicResampler struct {
 	aggregator       Aggregator
 	iterations       int
 	sampleAggregates []float64
+	values           []float64
 	r                rand.Source
+	concurrency      int
+	seed             int64
 }
 
 // NewBasicResampler returns a BasicResampler that aggregates values using aggregator.
@@ -89,12 +94,42 @@ func NewBasicResampler(aggregator Aggregator, iterations int) *BasicResampler {
 		iterations:       iterations,
 		sampleAggregates: make([]float64, 0, 100),
 		r:                rand.NewSource(0),
+		concurrency:      1,
+	}
+}
+
+// NewBasicResamplerWithOptions returns a BasicResampler that shards its
+// iterations across concurrency goroutines, each with its own rand.Source
+// seeded from seed plus a worker offset so results stay reproducible. A
+// concurrency of 0 or less uses runtime.GOMAXPROCS(0).
+func NewBasicResamplerWithOptions(aggregator Aggregator, iterations int, concurrency int, seed int64) *BasicResampler {
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	return &BasicResampler{
+		aggregator:       aggregator,
+		iterations:       iterations,
+		sampleAggregates: make([]float64, 0, 100),
+		r:                rand.NewSource(seed),
+		concurrency:      concurrency,
+		seed:             seed,
 	}
 }
 
 // Resample resamples from values for the given number of iterations and
 // saves the aggregate values.
 func (r *BasicResampler) Resample(values []float64) {
+	r.values = values
+	if r.concurrency <= 1 {
+		r.resampleSerial(values)
+		return
+	}
+	r.resampleConcurrent(values)
+}
+
+// resampleSerial resamples using the single shared rand.Source r.r,
+// preserving the exact draw sequence callers relying on r.r.Seed expect.
+func (r *BasicResampler) resampleSerial(values []float64) {
 	length := len(values)
 	scratch := make([]float64, length)
 	for i := 0; i < r.iterations; i++ {
@@ -106,6 +141,48 @@ func (r *BasicResampler) Resample(values []float64) {
 	sort.Float64s(r.sampleAggregates)
 }
 
+// resampleConcurrent shards r.iterations across r.concurrency goroutines,
+// each drawing from its own rand.Source and its own pooled scratch buffer.
+func (r *BasicResampler) resampleConcurrent(values []float64) {
+	length := len(values)
+	perWorker := r.iterations / r.concurrency
+	remainder := r.iterations % r.concurrency
+
+	results := make([][]float64, r.concurrency)
+	var wg sync.WaitGroup
+	for w := 0; w < r.concurrency; w++ {
+		n := perWorker
+		if w < remainder {
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(w, n int) {
+			defer wg.Done()
+			src := rand.NewSource(r.seed + int64(w))
+			scratch := getScratch(length)
+			defer putScratch(scratch)
+
+			local := make([]float64, 0, n)
+			for i := 0; i < n; i++ {
+				for j := range values {
+					scratch[j] = values[int(src.Int63())%length]
+				}
+				local = append(local, r.aggregator.Aggregate(scratch))
+			}
+			results[w] = local
+		}(w, n)
+	}
+	wg.Wait()
+
+	for _, local := range results {
+		r.sampleAggregates = append(r.sampleAggregates, local...)
+	}
+	sort.Float64s(r.sampleAggregates)
+}
+
 // Quantile returns the q quantile of resampled aggregate values.
 // Resample must be called before this method or NaN is returned.
 func (r *BasicResampler) Quantile(q float64) float64 {
@@ -123,12 +200,26 @@ type PresampledResampler struct {
 	aggregator       Aggregator
 	iterations       int
 	sampleAggregates []float64
+	values           []float64
 	samples          [][]int
+	concurrency      int
 }
 
 // NewPresampledResampler returns a PresampledResampler that aggregates values using aggregator.
 func NewPresampledResampler(aggregator Aggregator, iterations int, numValues int) *PresampledResampler {
-	r := rand.NewSource(0)
+	return NewPresampledResamplerWithOptions(aggregator, iterations, numValues, 1, 0)
+}
+
+// NewPresampledResamplerWithOptions returns a PresampledResampler that
+// precomputes sample indexes using the given seed and shards Resample's
+// iterations across concurrency goroutines. A concurrency of 0 or less uses
+// runtime.GOMAXPROCS(0).
+func NewPresampledResamplerWithOptions(aggregator Aggregator, iterations int, numValues int, concurrency int, seed int64) *PresampledResampler {
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	r := rand.NewSource(seed)
 	samples := make([][]int, iterations)
 	for i := range samples {
 		sampledInts := make([]int, numValues)
@@ -142,12 +233,22 @@ func NewPresampledResampler(aggregator Aggregator, iterations int, numValues int
 		iterations:       iterations,
 		sampleAggregates: make([]float64, 0, 100),
 		samples:          samples,
+		concurrency:      concurrency,
 	}
 }
 
 // Resample resamples from values for the given number of iterations and
 // saves the aggregate values.
 func (r *PresampledResampler) Resample(values []float64) {
+	r.values = values
+	if r.concurrency <= 1 {
+		r.resampleSerial(values)
+		return
+	}
+	r.resampleConcurrent(values)
+}
+
+func (r *PresampledResampler) resampleSerial(values []float64) {
 	length := len(values)
 	scratch := make([]float64, length)
 	for i := 0; i < r.iterations; i++ {
@@ -159,6 +260,49 @@ func (r *PresampledResampler) Resample(values []float64) {
 	sort.Float64s(r.sampleAggregates)
 }
 
+// resampleConcurrent shards r.iterations across r.concurrency goroutines,
+// each using its own pooled scratch buffer.
+func (r *PresampledResampler) resampleConcurrent(values []float64) {
+	length := len(values)
+	perWorker := r.iterations / r.concurrency
+	remainder := r.iterations % r.concurrency
+
+	results := make([][]float64, r.concurrency)
+	var wg sync.WaitGroup
+	start := 0
+	for w := 0; w < r.concurrency; w++ {
+		n := perWorker
+		if w < remainder {
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(w, start, n int) {
+			defer wg.Done()
+			scratch := getScratch(length)
+			defer putScratch(scratch)
+
+			local := make([]float64, 0, n)
+			for i := start; i < start+n; i++ {
+				for j := range values {
+					scratch[j] = values[j] * float64(r.samples[i][j])
+				}
+				local = append(local, r.aggregator.Aggregate(scratch))
+			}
+			results[w] = local
+		}(w, start, n)
+		start += n
+	}
+	wg.Wait()
+
+	for _, local := range results {
+		r.sampleAggregates = append(r.sampleAggregates, local...)
+	}
+	sort.Float64s(r.sampleAggregates)
+}
+
 // Quantile returns the q quantile of resampled aggregate values.
 // Resample must be called before this method or NaN is returned.
 func (r *PresampledResampler) Quantile(q float64) float64 {
@@ -67,6 +67,38 @@ func TestPresampledResampler(t *testing.T) {
 	}
 }
 
+// TestBasicResamplerWithOptions tests the concurrent resample path for
+// BasicResampler over many iterations, checking its min, max and median.
+func TestBasicResamplerWithOptions(t *testing.T) {
+	resampler := NewBasicResamplerWithOptions(SumAggregator{}, 20000, 4, 0)
+	resampler.Resample([]float64{0, 1, 2, 3, 4})
+	if min := resampler.Quantile(0); min != 0.0 {
+		t.Errorf("expected min to be %f; got %f", 0.0, min)
+	}
+	if max := resampler.Quantile(1); max != 20.0 {
+		t.Errorf("expected max to be %f; got %f", 20.0, max)
+	}
+	if median := resampler.Quantile(0.5); math.Abs(median-10.0) > 1.0 {
+		t.Errorf("expected median near %f; got %f", 10.0, median)
+	}
+}
+
+// TestPresampledResamplerWithOptions tests the concurrent resample path for
+// PresampledResampler.
+func TestPresampledResamplerWithOptions(t *testing.T) {
+	resampler := NewPresampledResamplerWithOptions(SumAggregator{}, 20000, 5, 4, 0)
+	resampler.Resample([]float64{0, 1, 2, 3, 4})
+	if min := resampler.Quantile(0); min != 0.0 {
+		t.Errorf("expected min to be %f; got %f", 0.0, min)
+	}
+	if max := resampler.Quantile(1); max != 20.0 {
+		t.Errorf("expected max to be %f; got %f", 20.0, max)
+	}
+	if median := resampler.Quantile(0.5); math.Abs(median-10.0) > 1.0 {
+		t.Errorf("expected median near %f; got %f", 10.0, median)
+	}
+}
+
 func BenchmarkResampler(b *testing.B) {
 	resampler := NewBasicResampler(SumAggregator{}, b.N)
 	resampler.r.Seed(0)
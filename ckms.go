@@ -0,0 +1,216 @@
+package bootstrap
+
+import (
+	"math"
+	"sort"
+)
+
+// ckmsSample is a single (value, g, delta) tuple in a CKMSSummary, as
+// described by Cormode, Korn, Muthukrishnan and Srivastava's "targeted
+// quantiles" algorithm. g is the number of observations covered since the
+// previous tuple, and delta is the maximum rank error for the tuple.
+type ckmsSample struct {
+	value float64
+	g     int
+	delta int
+}
+
+// CKMSSummary is an online summary that estimates quantiles of a stream of
+// float64s without retaining or sorting every observed value.
+type CKMSSummary struct {
+	epsilon   float64
+	invariant func(r, n float64) float64
+	samples   []ckmsSample
+	n         int
+	inserts   int
+}
+
+// Targeted returns a CKMSSummary that bounds rank error epsilon around the
+// given quantiles, per the invariant f(r,n) = min_q (2*epsilon*r/q) for
+// r <= q*n, and (2*epsilon*(n-r)/(1-q)) otherwise.
+func Targeted(epsilon float64, quantiles ...float64) *CKMSSummary {
+	targets := append([]float64(nil), quantiles...)
+	return &CKMSSummary{
+		epsilon: epsilon,
+		invariant: func(r, n float64) float64 {
+			min := math.Inf(1)
+			for _, q := range targets {
+				var f float64
+				if r <= q*n {
+					f = 2 * epsilon * r / q
+				} else {
+					f = 2 * epsilon * (n - r) / (1 - q)
+				}
+				if f < min {
+					min = f
+				}
+			}
+			return min
+		},
+	}
+}
+
+// LowBiased returns a CKMSSummary whose rank error bound is proportional to
+// rank, per the invariant f(r,n) = 2*epsilon*r, so it is smallest for low
+// quantiles. It is more
+// accurate than Targeted for low quantiles at the cost of more samples.
+func LowBiased(epsilon float64) *CKMSSummary {
+	return &CKMSSummary{
+		epsilon: epsilon,
+		invariant: func(r, n float64) float64 {
+			return 2 * epsilon * r
+		},
+	}
+}
+
+// Insert adds v to the summary, compressing periodically to bound the
+// number of retained samples.
+func (s *CKMSSummary) Insert(v float64) {
+	i := sort.Search(len(s.samples), func(i int) bool { return s.samples[i].value >= v })
+
+	delta := 0
+	if i != 0 && i != len(s.samples) {
+		r := 0.0
+		for _, sample := range s.samples[:i] {
+			r += float64(sample.g)
+		}
+		delta = int(math.Floor(s.invariant(r, float64(s.n)))) - 1
+		if delta < 0 {
+			delta = 0
+		}
+	}
+
+	s.samples = append(s.samples, ckmsSample{})
+	copy(s.samples[i+1:], s.samples[i:])
+	s.samples[i] = ckmsSample{value: v, g: 1, delta: delta}
+	s.n++
+
+	s.inserts++
+	if threshold := s.compressThreshold(); threshold > 0 && s.inserts >= threshold {
+		s.compress()
+		s.inserts = 0
+	}
+}
+
+// compressThreshold returns how many inserts should elapse between
+// compressions, per the 1/(2*epsilon) rule of thumb.
+func (s *CKMSSummary) compressThreshold() int {
+	if s.epsilon <= 0 {
+		return 0
+	}
+	return int(1 / (2 * s.epsilon))
+}
+
+// compress merges adjacent tuples that can be combined without violating
+// the summary's rank error invariant, scanning right-to-left.
+func (s *CKMSSummary) compress() {
+	if len(s.samples) < 3 {
+		return
+	}
+
+	n := float64(s.n)
+	prefix := make([]float64, len(s.samples))
+	r := 0.0
+	for i, sample := range s.samples {
+		prefix[i] = r
+		r += float64(sample.g)
+	}
+
+	merged := make([]ckmsSample, 0, len(s.samples))
+	merged = append(merged, s.samples[len(s.samples)-1])
+	for i := len(s.samples) - 2; i >= 0; i-- {
+		cur := s.samples[i]
+		last := &merged[len(merged)-1]
+		if float64(cur.g+last.g+last.delta) <= s.invariant(prefix[i], n) {
+			last.g += cur.g
+		} else {
+			merged = append(merged, cur)
+		}
+	}
+
+	for l, r := 0, len(merged)-1; l < r; l, r = l+1, r-1 {
+		merged[l], merged[r] = merged[r], merged[l]
+	}
+	s.samples = merged
+}
+
+// Query returns the approximate q quantile of all values inserted so far.
+func (s *CKMSSummary) Query(q float64) float64 {
+	if len(s.samples) == 0 {
+		return math.NaN()
+	}
+
+	n := float64(s.n)
+	target := q*n + s.invariant(q*n, n)/2
+
+	r := 0.0
+	for i, sample := range s.samples {
+		r += float64(sample.g)
+		if r+float64(sample.delta) > target {
+			if i == 0 {
+				return sample.value
+			}
+			return s.samples[i-1].value
+		}
+	}
+	return s.samples[len(s.samples)-1].value
+}
+
+// Merge combines other's samples into s, so summaries built by independent
+// resample workers can be combined into one. The merged tuples' deltas are
+// widened conservatively to preserve s's error guarantees.
+func (s *CKMSSummary) Merge(other *CKMSSummary) {
+	if other == nil || len(other.samples) == 0 {
+		return
+	}
+
+	merged := make([]ckmsSample, 0, len(s.samples)+len(other.samples))
+	i, j := 0, 0
+	for i < len(s.samples) && j < len(other.samples) {
+		if s.samples[i].value <= other.samples[j].value {
+			merged = append(merged, s.samples[i])
+			i++
+		} else {
+			merged = append(merged, other.samples[j])
+			j++
+		}
+	}
+	merged = append(merged, s.samples[i:]...)
+	merged = append(merged, other.samples[j:]...)
+
+	for k := range merged {
+		if k != 0 && k != len(merged)-1 {
+			merged[k].delta += merged[k].g - 1
+		}
+	}
+
+	s.samples = merged
+	s.n += other.n
+	s.compress()
+}
+
+// StreamingQuantileAggregator generates an approximate quantile using an
+// online CKMS summary, avoiding the O(n log n) sort.Float64s that
+// QuantileAggregator performs on every resample iteration.
+type StreamingQuantileAggregator struct {
+	quantile float64
+	epsilon  float64
+}
+
+// NewStreamingQuantileAggregator returns a new StreamingQuantileAggregator
+// that targets the given quantile with rank error bound epsilon.
+func NewStreamingQuantileAggregator(quantile, epsilon float64) StreamingQuantileAggregator {
+	return StreamingQuantileAggregator{
+		quantile: quantile,
+		epsilon:  epsilon,
+	}
+}
+
+// Aggregate returns the approximate a.quantile quantile of values.
+func (a StreamingQuantileAggregator) Aggregate(values []float64) float64 {
+	summary := Targeted(a.epsilon, a.quantile)
+	for _, v := range values {
+		summary.Insert(v)
+	}
+	return summary.Query(a.quantile)
+}
@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestCKMSSummaryTargeted tests that Targeted tracks a quantile within its
+// epsilon rank error bound over a uniform stream.
+func TestCKMSSummaryTargeted(t *testing.T) {
+	const n = 10000
+	const epsilon = 0.01
+	values := make([]float64, n)
+	r := rand.New(rand.NewSource(0))
+	for i := range values {
+		values[i] = r.Float64()
+	}
+
+	summary := Targeted(epsilon, 0.5)
+	for _, v := range values {
+		summary.Insert(v)
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	want := sorted[n/2]
+
+	got := summary.Query(0.5)
+	gotRank := float64(sort.SearchFloat64s(sorted, got))
+	if maxErr := epsilon * n; math.Abs(gotRank-n/2) > maxErr {
+		t.Errorf("expected median rank within %f of %d; got rank %f (want value %f, got %f)", maxErr, n/2, gotRank, want, got)
+	}
+}
+
+// TestCKMSSummaryMerge tests that merging two summaries built from
+// different halves of a stream still approximates the combined median.
+func TestCKMSSummaryMerge(t *testing.T) {
+	const n = 4000
+	const epsilon = 0.01
+	values := make([]float64, n)
+	r := rand.New(rand.NewSource(1))
+	for i := range values {
+		values[i] = r.Float64()
+	}
+
+	a := Targeted(epsilon, 0.5)
+	b := Targeted(epsilon, 0.5)
+	for i, v := range values {
+		if i%2 == 0 {
+			a.Insert(v)
+		} else {
+			b.Insert(v)
+		}
+	}
+	a.Merge(b)
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	want := sorted[n/2]
+
+	got := a.Query(0.5)
+	gotRank := float64(sort.SearchFloat64s(sorted, got))
+	if maxErr := epsilon * n; math.Abs(gotRank-n/2) > maxErr {
+		t.Errorf("expected merged median rank within %f of %d; got rank %f (want value %f, got %f)", maxErr, n/2, gotRank, want, got)
+	}
+}
+
+// TestStreamingQuantileAggregator tests StreamingQuantileAggregator with
+// BasicResampler, using the same seed and input as TestBasicResampler.
+func TestStreamingQuantileAggregator(t *testing.T) {
+	resampler := NewBasicResampler(NewStreamingQuantileAggregator(0.5, 0.01), 2000)
+	resampler.r.Seed(0)
+	resampler.Resample([]float64{0, 1, 2, 3, 4})
+	if median := resampler.Quantile(0.5); math.Abs(median-2.0) > 1.0 {
+		t.Errorf("expected median near %f; got %f", 2.0, median)
+	}
+}
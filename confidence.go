@@ -0,0 +1,134 @@
+package bootstrap
+
+import "math"
+
+// ConfidenceResampler is a Resampler that can additionally report a BCa
+// (bias-corrected and accelerated) confidence interval for its statistic.
+type ConfidenceResampler interface {
+	Resampler
+	ConfidenceInterval(alpha float64) (lo, hi float64)
+}
+
+// ConfidenceInterval returns the BCa alpha confidence interval for
+// r.aggregator applied to the original sample, using the bootstrap
+// replicates collected by Resample. Resample must be called before this
+// method or NaN, NaN is returned.
+func (r *BasicResampler) ConfidenceInterval(alpha float64) (lo, hi float64) {
+	return bcaInterval(r.aggregator, r.values, r.sampleAggregates, alpha)
+}
+
+// ConfidenceInterval returns the BCa alpha confidence interval for
+// r.aggregator applied to the original sample, using the bootstrap
+// replicates collected by Resample. Resample must be called before this
+// method or NaN, NaN is returned.
+func (r *PresampledResampler) ConfidenceInterval(alpha float64) (lo, hi float64) {
+	return bcaInterval(r.aggregator, r.values, r.sampleAggregates, alpha)
+}
+
+// bcaInterval computes the bias-corrected and accelerated confidence
+// interval for aggregator's statistic, given the original sample values and
+// the (already sorted) bootstrap replicates in sampleAggregates.
+func bcaInterval(aggregator Aggregator, values []float64, sampleAggregates []float64, alpha float64) (lo, hi float64) {
+	n := len(values)
+	if n == 0 || len(sampleAggregates) == 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	theta := aggregator.Aggregate(append([]float64(nil), values...))
+
+	below := 0
+	for _, replicate := range sampleAggregates {
+		if replicate < theta {
+			below++
+		}
+	}
+	z0 := normInv(float64(below) / float64(len(sampleAggregates)))
+
+	jackknife := make([]float64, n)
+	leaveOneOut := make([]float64, n-1)
+	for i := 0; i < n; i++ {
+		copy(leaveOneOut, values[:i])
+		copy(leaveOneOut[i:], values[i+1:])
+		jackknife[i] = aggregator.Aggregate(append([]float64(nil), leaveOneOut...))
+	}
+
+	mean := 0.0
+	for _, v := range jackknife {
+		mean += v
+	}
+	mean /= float64(n)
+
+	var num, den float64
+	for _, v := range jackknife {
+		d := mean - v
+		num += d * d * d
+		den += d * d
+	}
+	a := num / (6 * math.Pow(den, 1.5))
+
+	zLo := normInv(alpha / 2)
+	zHi := normInv(1 - alpha/2)
+	alpha1 := normCDF(z0 + (z0+zLo)/(1-a*(z0+zLo)))
+	alpha2 := normCDF(z0 + (z0+zHi)/(1-a*(z0+zHi)))
+
+	return quantile(sampleAggregates, alpha1), quantile(sampleAggregates, alpha2)
+}
+
+// normCDF returns the standard normal cumulative distribution function at x.
+func normCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// normInv returns the inverse standard normal CDF (probit function) at p,
+// via Acklam's rational approximation.
+func normInv(p float64) float64 {
+	const (
+		a1 = -3.969683028665376e+01
+		a2 = 2.209460984245205e+02
+		a3 = -2.759285104469687e+02
+		a4 = 1.383577518672690e+02
+		a5 = -3.066479806614716e+01
+		a6 = 2.506628277459239e+00
+
+		b1 = -5.447609879822406e+01
+		b2 = 1.615858368580409e+02
+		b3 = -1.556989798598866e+02
+		b4 = 6.680131188771972e+01
+		b5 = -1.328068155288572e+01
+
+		c1 = -7.784894002430293e-03
+		c2 = -3.223964580411365e-01
+		c3 = -2.400758277161838e+00
+		c4 = -2.549732539343734e+00
+		c5 = 4.374664141464968e+00
+		c6 = 2.938163982698783e+00
+
+		d1 = 7.784695709041462e-03
+		d2 = 3.224671290700398e-01
+		d3 = 2.445134137142996e+00
+		d4 = 3.754408661907416e+00
+
+		pLow  = 0.02425
+		pHigh = 1 - pLow
+	)
+
+	switch {
+	case p <= 0:
+		return math.Inf(-1)
+	case p >= 1:
+		return math.Inf(1)
+	case p < pLow:
+		q := math.Sqrt(-2 * math.Log(p))
+		return (((((c1*q+c2)*q+c3)*q+c4)*q+c5)*q + c6) /
+			((((d1*q+d2)*q+d3)*q+d4)*q + 1)
+	case p <= pHigh:
+		q := p - 0.5
+		r := q * q
+		return (((((a1*r+a2)*r+a3)*r+a4)*r+a5)*r + a6) * q /
+			(((((b1*r+b2)*r+b3)*r+b4)*r+b5)*r + 1)
+	default:
+		q := math.Sqrt(-2 * math.Log(1-p))
+		return -(((((c1*q+c2)*q+c3)*q+c4)*q+c5)*q + c6) /
+			((((d1*q+d2)*q+d3)*q+d4)*q + 1)
+	}
+}
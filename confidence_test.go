@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestBasicResamplerConfidenceInterval tests that the BCa interval for an
+// average is non-empty and brackets the true mean of a normal sample.
+func TestBasicResamplerConfidenceInterval(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	values := make([]float64, 200)
+	for i := range values {
+		values[i] = 10 + r.NormFloat64()
+	}
+
+	resampler := NewBasicResampler(AverageAggregator{}, 2000)
+	resampler.Resample(values)
+
+	lo, hi := resampler.ConfidenceInterval(0.05)
+	if lo >= hi {
+		t.Fatalf("expected lo < hi; got lo=%f hi=%f", lo, hi)
+	}
+	if lo > 10.0 || hi < 10.0 {
+		t.Errorf("expected [%f, %f] to bracket the true mean 10.0", lo, hi)
+	}
+}
+
+// TestBasicResamplerConfidenceIntervalEmpty tests that ConfidenceInterval
+// returns NaN before Resample has been called.
+func TestBasicResamplerConfidenceIntervalEmpty(t *testing.T) {
+	resampler := NewBasicResampler(AverageAggregator{}, 2000)
+	lo, hi := resampler.ConfidenceInterval(0.05)
+	if !math.IsNaN(lo) || !math.IsNaN(hi) {
+		t.Errorf("expected NaN, NaN; got %f, %f", lo, hi)
+	}
+}
+
+// TestPresampledResamplerConfidenceInterval mirrors
+// TestBasicResamplerConfidenceInterval for PresampledResampler.
+func TestPresampledResamplerConfidenceInterval(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]float64, 200)
+	for i := range values {
+		values[i] = 10 + r.NormFloat64()
+	}
+
+	resampler := NewPresampledResampler(AverageAggregator{}, 2000, len(values))
+	resampler.Resample(values)
+
+	lo, hi := resampler.ConfidenceInterval(0.05)
+	if lo >= hi {
+		t.Fatalf("expected lo < hi; got lo=%f hi=%f", lo, hi)
+	}
+	if lo > 10.0 || hi < 10.0 {
+		t.Errorf("expected [%f, %f] to bracket the true mean 10.0", lo, hi)
+	}
+}
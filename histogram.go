@@ -0,0 +1,213 @@
+package bootstrap
+
+import (
+	"math"
+	"sort"
+)
+
+// histogramBin is a single bucket of a Histogram, tracking the count and
+// sum of the values that fall into it.
+type histogramBin struct {
+	Count int
+	Sum   float64
+}
+
+// Mean returns the average value represented by the bin.
+func (b histogramBin) Mean() float64 {
+	return b.Sum / float64(b.Count)
+}
+
+// Histogram is a bounded-size online histogram, as described by Ben-Haim
+// and Tom-Tov's streaming decision-tree histograms. It holds at most
+// maxBins bins, merging the closest pair by mean whenever that bound would
+// be exceeded, trading bin resolution for bounded memory.
+type Histogram struct {
+	maxBins int
+	bins    []histogramBin
+}
+
+// NewHistogram returns a new Histogram that retains at most maxBins bins.
+func NewHistogram(maxBins int) *Histogram {
+	return &Histogram{maxBins: maxBins}
+}
+
+// Insert adds x to the histogram, merging bins if the bin count would
+// exceed maxBins.
+func (h *Histogram) Insert(x float64) {
+	i := sort.Search(len(h.bins), func(i int) bool { return h.bins[i].Mean() >= x })
+	if i < len(h.bins) && h.bins[i].Mean() == x {
+		h.bins[i].Count++
+		h.bins[i].Sum += x
+		return
+	}
+
+	h.bins = append(h.bins, histogramBin{})
+	copy(h.bins[i+1:], h.bins[i:])
+	h.bins[i] = histogramBin{Count: 1, Sum: x}
+
+	if len(h.bins) > h.maxBins {
+		h.mergeClosestPair()
+	}
+}
+
+// mergeClosestPair merges the adjacent pair of bins with the smallest
+// difference in mean.
+func (h *Histogram) mergeClosestPair() {
+	if len(h.bins) < 2 {
+		return
+	}
+
+	minGap := math.Inf(1)
+	minIndex := 0
+	for i := 0; i < len(h.bins)-1; i++ {
+		gap := h.bins[i+1].Mean() - h.bins[i].Mean()
+		if gap < minGap {
+			minGap = gap
+			minIndex = i
+		}
+	}
+
+	h.bins[minIndex].Count += h.bins[minIndex+1].Count
+	h.bins[minIndex].Sum += h.bins[minIndex+1].Sum
+	h.bins = append(h.bins[:minIndex+1], h.bins[minIndex+2:]...)
+}
+
+// Merge combines other's bins into h, merging the closest pairs until the
+// result again fits within h.maxBins. This allows per-iteration histograms
+// from parallel resample workers to be combined into one.
+func (h *Histogram) Merge(other *Histogram) {
+	if other == nil || len(other.bins) == 0 {
+		return
+	}
+
+	merged := make([]histogramBin, 0, len(h.bins)+len(other.bins))
+	i, j := 0, 0
+	for i < len(h.bins) && j < len(other.bins) {
+		if h.bins[i].Mean() <= other.bins[j].Mean() {
+			merged = append(merged, h.bins[i])
+			i++
+		} else {
+			merged = append(merged, other.bins[j])
+			j++
+		}
+	}
+	merged = append(merged, h.bins[i:]...)
+	merged = append(merged, other.bins[j:]...)
+	h.bins = merged
+
+	for len(h.bins) > h.maxBins {
+		h.mergeClosestPair()
+	}
+}
+
+// count returns the total number of values inserted into h.
+func (h *Histogram) count() int {
+	total := 0
+	for _, b := range h.bins {
+		total += b.Count
+	}
+	return total
+}
+
+// Sum returns the sum of all inserted values.
+func (h *Histogram) Sum() float64 {
+	total := 0.0
+	for _, b := range h.bins {
+		total += b.Sum
+	}
+	return total
+}
+
+// Mean returns the average of all inserted values.
+func (h *Histogram) Mean() float64 {
+	if len(h.bins) == 0 {
+		return math.NaN()
+	}
+	return h.Sum() / float64(h.count())
+}
+
+// rank returns the approximate number of inserted values at most x, via
+// linear interpolation between the bins bracketing x.
+func (h *Histogram) rank(x float64) float64 {
+	if len(h.bins) == 0 {
+		return math.NaN()
+	}
+	if x <= h.bins[0].Mean() {
+		return 0
+	}
+	if x >= h.bins[len(h.bins)-1].Mean() {
+		return float64(h.count())
+	}
+
+	i := sort.Search(len(h.bins), func(i int) bool { return h.bins[i].Mean() > x }) - 1
+	lo, hi := h.bins[i], h.bins[i+1]
+
+	frac := (x - lo.Mean()) / (hi.Mean() - lo.Mean())
+	mb := float64(lo.Count) + frac*float64(hi.Count-lo.Count)
+	segment := (float64(lo.Count) + mb) / 2 * frac
+
+	before := 0.0
+	for _, b := range h.bins[:i] {
+		before += float64(b.Count)
+	}
+	return before + float64(lo.Count)/2 + segment
+}
+
+// CDF returns the fraction of inserted values at most x.
+func (h *Histogram) CDF(x float64) float64 {
+	total := h.count()
+	if total == 0 {
+		return math.NaN()
+	}
+	return h.rank(x) / float64(total)
+}
+
+// Quantile returns the approximate q quantile of inserted values, found by
+// bisecting rank against the target rank q*count.
+func (h *Histogram) Quantile(q float64) float64 {
+	if len(h.bins) == 0 {
+		return math.NaN()
+	}
+
+	lo, hi := h.bins[0].Mean(), h.bins[len(h.bins)-1].Mean()
+	if lo == hi {
+		return lo
+	}
+
+	target := q * float64(h.count())
+	for iter := 0; iter < 50; iter++ {
+		mid := lo + (hi-lo)/2
+		if h.rank(mid) < target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + (hi-lo)/2
+}
+
+// HistogramAggregator generates an approximate quantile using a bounded
+// online histogram, giving O(maxBins) memory per resample iteration instead
+// of materializing the full sample slice.
+type HistogramAggregator struct {
+	quantile float64
+	maxBins  int
+}
+
+// NewHistogramAggregator returns a new HistogramAggregator that targets the
+// given quantile using at most maxBins histogram bins.
+func NewHistogramAggregator(quantile float64, maxBins int) HistogramAggregator {
+	return HistogramAggregator{
+		quantile: quantile,
+		maxBins:  maxBins,
+	}
+}
+
+// Aggregate returns the approximate a.quantile quantile of values.
+func (a HistogramAggregator) Aggregate(values []float64) float64 {
+	h := NewHistogram(a.maxBins)
+	for _, v := range values {
+		h.Insert(v)
+	}
+	return h.Quantile(a.quantile)
+}
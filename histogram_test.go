@@ -0,0 +1,114 @@
+package bootstrap
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestHistogramQuantileExponential tests Histogram quantile accuracy
+// against an exponentially distributed sample.
+func TestHistogramQuantileExponential(t *testing.T) {
+	const n = 20000
+	const maxBins = 64
+	r := rand.New(rand.NewSource(0))
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = r.ExpFloat64()
+	}
+	testHistogramQuantiles(t, values, maxBins)
+}
+
+// TestHistogramQuantileNormal tests Histogram quantile accuracy against a
+// normally distributed sample.
+func TestHistogramQuantileNormal(t *testing.T) {
+	const n = 20000
+	const maxBins = 64
+	r := rand.New(rand.NewSource(1))
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = r.NormFloat64()
+	}
+	testHistogramQuantiles(t, values, maxBins)
+}
+
+func testHistogramQuantiles(t *testing.T, values []float64, maxBins int) {
+	h := NewHistogram(maxBins)
+	for _, v := range values {
+		h.Insert(v)
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	// The Ben-Haim/Tom-Tov bound on rank error for a maxBins-bucket
+	// histogram is O(n/maxBins); allow a few multiples of headroom.
+	maxRankErr := float64(len(values)) / float64(maxBins) * 4
+
+	for _, q := range []float64{0.5, 0.9, 0.99} {
+		want := quantile(sorted, q)
+		got := h.Quantile(q)
+		wantRank := float64(sort.SearchFloat64s(sorted, got))
+		rankErr := math.Abs(wantRank - q*float64(len(sorted)))
+		if rankErr > maxRankErr {
+			t.Errorf("quantile %f: rank error %f exceeds bound %f (want value %f, got %f)", q, rankErr, maxRankErr, want, got)
+		}
+	}
+}
+
+// TestHistogramMeanSum tests Histogram Mean and Sum against exact values.
+func TestHistogramMeanSum(t *testing.T) {
+	h := NewHistogram(8)
+	values := []float64{0, 1, 2, 3, 4}
+	for _, v := range values {
+		h.Insert(v)
+	}
+	if sum := h.Sum(); sum != 10.0 {
+		t.Errorf("expected sum %f; got %f", 10.0, sum)
+	}
+	if mean := h.Mean(); mean != 2.0 {
+		t.Errorf("expected mean %f; got %f", 2.0, mean)
+	}
+}
+
+// TestHistogramMerge tests that merging two histograms built from different
+// halves of a stream approximates the combined median.
+func TestHistogramMerge(t *testing.T) {
+	const n = 8000
+	const maxBins = 32
+	r := rand.New(rand.NewSource(2))
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = r.Float64()
+	}
+
+	a := NewHistogram(maxBins)
+	b := NewHistogram(maxBins)
+	for i, v := range values {
+		if i%2 == 0 {
+			a.Insert(v)
+		} else {
+			b.Insert(v)
+		}
+	}
+	a.Merge(b)
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	want := quantile(sorted, 0.5)
+
+	if got := a.Quantile(0.5); math.Abs(got-want) > 0.05 {
+		t.Errorf("expected merged median within 0.05 of %f; got %f", want, got)
+	}
+}
+
+// TestHistogramAggregator tests HistogramAggregator against BasicResampler.
+func TestHistogramAggregator(t *testing.T) {
+	resampler := NewBasicResampler(NewHistogramAggregator(0.5, 16), 2000)
+	resampler.r.Seed(0)
+	resampler.Resample([]float64{0, 1, 2, 3, 4})
+	if median := resampler.Quantile(0.5); math.Abs(median-2.0) > 1.0 {
+		t.Errorf("expected median near %f; got %f", 2.0, median)
+	}
+}
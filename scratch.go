@@ -0,0 +1,26 @@
+package bootstrap
+
+import "sync"
+
+// scratchPool holds reusable []float64 buffers for resample workers, so a
+// fresh scratch slice doesn't need to be allocated on every Resample call.
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		return new([]float64)
+	},
+}
+
+// getScratch returns a []float64 of the given length, reusing a pooled
+// buffer when its capacity is large enough.
+func getScratch(length int) []float64 {
+	buf := scratchPool.Get().(*[]float64)
+	if cap(*buf) < length {
+		return make([]float64, length)
+	}
+	return (*buf)[:length]
+}
+
+// putScratch returns scratch to the pool for reuse by later workers.
+func putScratch(scratch []float64) {
+	scratchPool.Put(&scratch)
+}
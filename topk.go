@@ -0,0 +1,168 @@
+package bootstrap
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// WeightedAggregator aggregates parallel slices of ids and weights,
+// analogous to Aggregator but for categorical (id, weight) streams rather
+// than plain floats.
+type WeightedAggregator interface {
+	Aggregate(ids []uint64, weights []float64) []Entry
+}
+
+// Entry is a tracked id in a TopKAggregator or WeightedResampler result,
+// with a confidence bound [Count-Error, Count] on its true weight.
+type Entry struct {
+	ID    uint64
+	Count float64
+	Error float64
+}
+
+// spaceSavingSlot is a single tracked (id, count, error) slot in a
+// SpaceSaving summary.
+type spaceSavingSlot struct {
+	id    uint64
+	count float64
+	err   float64
+}
+
+// SpaceSaving is an online summary that tracks the k largest-weight ids
+// from a stream of (id, weight) pairs, per Metwally, Agrawal and Abbadi's
+// "Space-Saving" algorithm.
+type SpaceSaving struct {
+	k     int
+	slots []spaceSavingSlot
+	index map[uint64]int
+}
+
+// NewSpaceSaving returns a new SpaceSaving summary that tracks at most k ids.
+func NewSpaceSaving(k int) *SpaceSaving {
+	return &SpaceSaving{
+		k:     k,
+		index: make(map[uint64]int, k),
+	}
+}
+
+// Observe adds weight w to id's tracked count, or, if id isn't tracked and
+// there's no free slot, evicts the minimum-count slot in its favor.
+func (s *SpaceSaving) Observe(id uint64, w float64) {
+	if i, ok := s.index[id]; ok {
+		s.slots[i].count += w
+		return
+	}
+
+	if len(s.slots) < s.k {
+		s.slots = append(s.slots, spaceSavingSlot{id: id, count: w})
+		s.index[id] = len(s.slots) - 1
+		return
+	}
+
+	minIndex := 0
+	for i := 1; i < len(s.slots); i++ {
+		if s.slots[i].count < s.slots[minIndex].count {
+			minIndex = i
+		}
+	}
+
+	minCount := s.slots[minIndex].count
+	delete(s.index, s.slots[minIndex].id)
+	s.slots[minIndex] = spaceSavingSlot{id: id, count: minCount + w, err: minCount}
+	s.index[id] = minIndex
+}
+
+// TopK returns the tracked entries sorted by count, descending.
+func (s *SpaceSaving) TopK() []Entry {
+	entries := make([]Entry, len(s.slots))
+	for i, slot := range s.slots {
+		entries[i] = Entry{ID: slot.id, Count: slot.count, Error: slot.err}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Count > entries[j].Count })
+	return entries
+}
+
+// TopKAggregator generates the k largest-weight ids from an (id, weight)
+// stream using a SpaceSaving summary.
+type TopKAggregator struct {
+	k int
+}
+
+// NewTopKAggregator returns a new TopKAggregator that tracks the top k ids.
+func NewTopKAggregator(k int) TopKAggregator {
+	return TopKAggregator{k: k}
+}
+
+// Aggregate returns the top a.k ids by summed weight.
+func (a TopKAggregator) Aggregate(ids []uint64, weights []float64) []Entry {
+	s := NewSpaceSaving(a.k)
+	for i, id := range ids {
+		s.Observe(id, weights[i])
+	}
+	return s.TopK()
+}
+
+// WeightedResampler is a bootstrap resampler over (id, weight) pairs,
+// resampling index positions with replacement and feeding the resulting
+// (id, weight) pairs into a WeightedAggregator on each iteration.
+type WeightedResampler struct {
+	aggregator WeightedAggregator
+	iterations int
+	r          rand.Source
+	results    [][]Entry
+}
+
+// NewWeightedResampler returns a WeightedResampler that aggregates
+// (id, weight) pairs using aggregator.
+func NewWeightedResampler(aggregator WeightedAggregator, iterations int) *WeightedResampler {
+	return &WeightedResampler{
+		aggregator: aggregator,
+		iterations: iterations,
+		r:          rand.NewSource(0),
+		results:    make([][]Entry, 0, iterations),
+	}
+}
+
+// Resample resamples index positions from ids/weights with replacement for
+// the given number of iterations and saves each iteration's aggregate.
+func (r *WeightedResampler) Resample(ids []uint64, weights []float64) {
+	length := len(ids)
+	scratchIDs := make([]uint64, length)
+	scratchWeights := make([]float64, length)
+	for i := 0; i < r.iterations; i++ {
+		for j := 0; j < length; j++ {
+			idx := int(r.r.Int63()) % length
+			scratchIDs[j] = ids[idx]
+			scratchWeights[j] = weights[idx]
+		}
+		r.results = append(r.results, r.aggregator.Aggregate(scratchIDs, scratchWeights))
+	}
+}
+
+// TopK returns, for every id that appeared in any iteration's top-k, its
+// mean count and error across all resample iterations, sorted by count
+// descending. This summarizes how consistently an id is a heavy hitter
+// across bootstrap replicates.
+func (r *WeightedResampler) TopK() []Entry {
+	counts := make(map[uint64]float64)
+	errs := make(map[uint64]float64)
+	for _, entries := range r.results {
+		for _, e := range entries {
+			counts[e.ID] += e.Count
+			errs[e.ID] += e.Error
+		}
+	}
+
+	n := float64(len(r.results))
+	result := make([]Entry, 0, len(counts))
+	for id, sum := range counts {
+		result = append(result, Entry{ID: id, Count: sum / n, Error: errs[id] / n})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Count > result[j].Count })
+	return result
+}
+
+// Reset resets any sampled state.
+func (r *WeightedResampler) Reset() {
+	r.results = r.results[:0]
+}
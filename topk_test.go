@@ -0,0 +1,56 @@
+package bootstrap
+
+import "testing"
+
+// TestSpaceSaving tests that SpaceSaving tracks the heaviest ids when the
+// stream has more distinct ids than tracked slots.
+func TestSpaceSaving(t *testing.T) {
+	s := NewSpaceSaving(3)
+	s.Observe(1, 100)
+	s.Observe(2, 50)
+	s.Observe(3, 1)
+	s.Observe(4, 1)
+
+	top := s.TopK()
+	if len(top) != 3 {
+		t.Fatalf("expected 3 tracked entries; got %d", len(top))
+	}
+	if top[0].ID != 1 {
+		t.Errorf("expected heaviest id to be 1; got %d", top[0].ID)
+	}
+	if top[1].ID != 2 {
+		t.Errorf("expected second heaviest id to be 2; got %d", top[1].ID)
+	}
+}
+
+// TestTopKAggregator tests TopKAggregator against a simple weighted stream.
+func TestTopKAggregator(t *testing.T) {
+	agg := NewTopKAggregator(2)
+	entries := agg.Aggregate([]uint64{1, 2, 3, 2}, []float64{100, 5, 1, 5})
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries; got %d", len(entries))
+	}
+	if entries[0].ID != 1 || entries[0].Count != 100 {
+		t.Errorf("expected top entry {1, 100}; got %+v", entries[0])
+	}
+}
+
+// TestWeightedResampler tests that WeightedResampler surfaces the clearly
+// heaviest id across bootstrap iterations.
+func TestWeightedResampler(t *testing.T) {
+	resampler := NewWeightedResampler(NewTopKAggregator(2), 200)
+	resampler.Resample([]uint64{1, 2, 3, 4}, []float64{100, 1, 1, 1})
+
+	top := resampler.TopK()
+	if len(top) == 0 {
+		t.Fatal("expected at least one tracked entry")
+	}
+	if top[0].ID != 1 {
+		t.Errorf("expected heaviest id to be 1; got %d", top[0].ID)
+	}
+
+	resampler.Reset()
+	if top := resampler.TopK(); len(top) != 0 {
+		t.Errorf("expected no entries after reset; got %d", len(top))
+	}
+}